@@ -0,0 +1,319 @@
+package record_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tilotech/tilores-insights/helpers"
+	"github.com/tilotech/tilores-insights/record"
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+func valueRecords(t *testing.T, values []any) []*api.Record {
+	t.Helper()
+	records := make([]*api.Record, 0, len(values))
+	for i, value := range values {
+		dataJSON, err := json.Marshal(map[string]any{"value": value})
+		require.NoError(t, err)
+		data := map[string]any{}
+		require.NoError(t, json.Unmarshal(dataJSON, &data))
+		records = append(records, &api.Record{
+			ID:   string(rune('a' + i)),
+			Data: data,
+		})
+	}
+	return records
+}
+
+func TestSum(t *testing.T) {
+	cases := map[string]struct {
+		values      []any
+		expected    *float64
+		expectError bool
+	}{
+		"no records": {
+			expected: nil,
+		},
+		"all null": {
+			values:   []any{nil, nil},
+			expected: nil,
+		},
+		"mixed": {
+			values:   []any{1, 2, nil, 3},
+			expected: helpers.NullifyFloat(6.0),
+		},
+		"negative numbers": {
+			values:   []any{-1, -2, 3},
+			expected: helpers.NullifyFloat(0.0),
+		},
+		"extraction error propagates": {
+			values:      []any{1, map[string]any{"x": 1.0}},
+			expectError: true,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := record.Sum(valueRecords(t, c.values), "value")
+			if c.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assertFloatPointerEqual(t, c.expected, actual)
+		})
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	values := []any{3, 1, nil, 2}
+	records := valueRecords(t, values)
+
+	min, err := record.Min(records, "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(1.0), min)
+
+	max, err := record.Max(records, "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(3.0), max)
+
+	emptyMin, err := record.Min(nil, "value")
+	require.NoError(t, err)
+	assert.Nil(t, emptyMin)
+
+	negatives := valueRecords(t, []any{-5, -1, -10})
+	negMin, err := record.Min(negatives, "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(-10.0), negMin)
+	negMax, err := record.Max(negatives, "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(-1.0), negMax)
+
+	invalid := valueRecords(t, []any{1, map[string]any{"x": 1.0}})
+	_, err = record.Min(invalid, "value")
+	assert.Error(t, err)
+	_, err = record.Max(invalid, "value")
+	assert.Error(t, err)
+}
+
+func TestMedianAndPercentile(t *testing.T) {
+	records := valueRecords(t, []any{1, 2, 3, 4})
+
+	median, err := record.Median(records, "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(2.5), median)
+
+	p0, err := record.Percentile(records, "value", 0)
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(1.0), p0)
+
+	p100, err := record.Percentile(records, "value", 100)
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(4.0), p100)
+
+	single, err := record.Median(valueRecords(t, []any{5}), "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(5.0), single)
+
+	empty, err := record.Median(nil, "value")
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+
+	_, err = record.Percentile(records, "value", -20)
+	assert.Error(t, err)
+
+	_, err = record.Percentile(records, "value", 150)
+	assert.Error(t, err)
+
+	p25, err := record.Percentile(records, "value", 25)
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(1.75), p25)
+
+	invalid := valueRecords(t, []any{1, map[string]any{"x": 1.0}})
+	_, err = record.Percentile(invalid, "value", 50)
+	assert.Error(t, err)
+}
+
+func TestVariance(t *testing.T) {
+	records := valueRecords(t, []any{1, 2, 3})
+	variance, err := record.Variance(records, "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(2.0/3.0), variance)
+
+	empty, err := record.Variance(nil, "value")
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+
+	invalid := valueRecords(t, []any{1, map[string]any{"x": 1.0}})
+	_, err = record.Variance(invalid, "value")
+	assert.Error(t, err)
+}
+
+func TestMode(t *testing.T) {
+	records := valueRecords(t, []any{1, 2, 2, 3})
+	mode, err := record.Mode(records, "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(2.0), mode)
+
+	empty, err := record.Mode(nil, "value")
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+
+	tie, err := record.Mode(valueRecords(t, []any{3, 1, 1, 3}), "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(1.0), tie)
+
+	invalid := valueRecords(t, []any{1, map[string]any{"x": 1.0}})
+	_, err = record.Mode(invalid, "value")
+	assert.Error(t, err)
+}
+
+func TestTextMode(t *testing.T) {
+	records := valueRecords(t, []any{"abc", "ABC", "abd"})
+	mode, err := record.TextMode(records, "value", false)
+	require.NoError(t, err)
+	require.NotNil(t, mode)
+	assert.Equal(t, "abc", *mode)
+
+	caseSensitive, err := record.TextMode(records, "value", true)
+	require.NoError(t, err)
+	require.NotNil(t, caseSensitive)
+	assert.Equal(t, "ABC", *caseSensitive)
+
+	empty, err := record.TextMode(nil, "value", false)
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+
+	tie, err := record.TextMode(valueRecords(t, []any{"bb", "aa"}), "value", true)
+	require.NoError(t, err)
+	require.NotNil(t, tie)
+	assert.Equal(t, "aa", *tie)
+}
+
+func TestCount(t *testing.T) {
+	records := valueRecords(t, []any{1, nil, 3})
+	count, err := record.Count(records, "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(2.0), count)
+
+	empty, err := record.Count(valueRecords(t, []any{nil, nil}), "value")
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+}
+
+func TestCountDistinct(t *testing.T) {
+	records := valueRecords(t, []any{"abc", "abc", "def", nil})
+	count, err := record.CountDistinct(records, "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(2.0), count)
+
+	empty, err := record.CountDistinct(nil, "value")
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+
+	caseSensitiveDistinct, err := record.CountDistinct(valueRecords(t, []any{"abc", "ABC"}), "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(2.0), caseSensitiveDistinct)
+
+	numbers, err := record.CountDistinct(valueRecords(t, []any{1, 1, 2, 3}), "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(3.0), numbers)
+}
+
+func TestHistogram(t *testing.T) {
+	records := valueRecords(t, []any{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	buckets, err := record.Histogram(records, "value", 3)
+	require.NoError(t, err)
+	require.Len(t, buckets, 3)
+	total := 0
+	for _, bucket := range buckets {
+		total += bucket.Count
+	}
+	assert.Equal(t, 10, total)
+
+	_, err = record.Histogram(records, "value", 0)
+	assert.Error(t, err)
+
+	empty, err := record.Histogram(nil, "value", 3)
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+
+	single, err := record.Histogram(valueRecords(t, []any{5, 5, 5}), "value", 2)
+	require.NoError(t, err)
+	require.Len(t, single, 2)
+	assert.Equal(t, 3, single[0].Count)
+
+	_, err = record.Histogram(records, "value", -1)
+	assert.Error(t, err)
+
+	negatives, err := record.Histogram(valueRecords(t, []any{-10, 0, 10}), "value", 2)
+	require.NoError(t, err)
+	require.Len(t, negatives, 2)
+	assert.Equal(t, -10.0, negatives[0].Lower)
+	assert.Equal(t, 10.0, negatives[1].Upper)
+
+	invalid := valueRecords(t, []any{1, map[string]any{"x": 1.0}})
+	_, err = record.Histogram(invalid, "value", 2)
+	assert.Error(t, err)
+}
+
+func TestGroupBy(t *testing.T) {
+	records := make([]*api.Record, 0, 3)
+	for i, entry := range []struct {
+		country string
+		amount  float64
+	}{
+		{"DE", 1},
+		{"DE", 3},
+		{"US", 10},
+	} {
+		dataJSON, err := json.Marshal(map[string]any{
+			"country": entry.country,
+			"amount":  entry.amount,
+		})
+		require.NoError(t, err)
+		data := map[string]any{}
+		require.NoError(t, json.Unmarshal(dataJSON, &data))
+		records = append(records, &api.Record{ID: string(rune('a' + i)), Data: data})
+	}
+
+	result, err := record.GroupBy(records, "country", func(group []*api.Record) (any, error) {
+		return record.Average(group, "amount")
+	})
+	require.NoError(t, err)
+	require.Contains(t, result, "DE")
+	require.Contains(t, result, "US")
+	assertFloatPointerEqual(t, helpers.NullifyFloat(2.0), result["DE"].(*float64))
+	assertFloatPointerEqual(t, helpers.NullifyFloat(10.0), result["US"].(*float64))
+
+	errGroupBy := errors.New("agg failed")
+	_, err = record.GroupBy(records, "country", func(group []*api.Record) (any, error) {
+		return nil, errGroupBy
+	})
+	assert.ErrorIs(t, err, errGroupBy)
+
+	withoutGroup := make([]*api.Record, 0, 1)
+	dataJSON, err := json.Marshal(map[string]any{"amount": 5.0})
+	require.NoError(t, err)
+	data := map[string]any{}
+	require.NoError(t, json.Unmarshal(dataJSON, &data))
+	withoutGroup = append(withoutGroup, &api.Record{ID: "no-country", Data: data})
+
+	emptyResult, err := record.GroupBy(withoutGroup, "country", func(group []*api.Record) (any, error) {
+		return record.Average(group, "amount")
+	})
+	require.NoError(t, err)
+	assert.Empty(t, emptyResult)
+}
+
+func assertFloatPointerEqual(t *testing.T, expected, actual *float64) {
+	t.Helper()
+	if expected == nil {
+		assert.Nil(t, actual)
+		return
+	}
+	require.NotNil(t, actual)
+	assert.InDelta(t, *expected, *actual, 0.0000001)
+}
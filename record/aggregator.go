@@ -0,0 +1,217 @@
+package record
+
+import (
+	"math"
+
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+// Aggregator incrementally aggregates records pushed one at a time, so that
+// callers do not need to hold the full record set in memory.
+type Aggregator interface {
+	// Push incorporates a single record into the aggregation.
+	Push(r *api.Record) error
+	// Result returns the aggregation result computed so far, following the
+	// same nil-when-nothing-counted contract as the non-streaming functions.
+	Result() (any, error)
+}
+
+// Combine returns an Aggregator that fans a single Push out to every given
+// aggregator and whose Result returns their individual results, in order.
+// This allows a record set to be walked once while feeding several
+// aggregations at the same time.
+func Combine(aggs ...Aggregator) Aggregator {
+	return &combinedAggregator{aggs: aggs}
+}
+
+type combinedAggregator struct {
+	aggs []Aggregator
+}
+
+func (c *combinedAggregator) Push(r *api.Record) error {
+	for _, agg := range c.aggs {
+		if err := agg.Push(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *combinedAggregator) Result() (any, error) {
+	results := make([]any, len(c.aggs))
+	for i, agg := range c.aggs {
+		result, err := agg.Result()
+		if err != nil {
+			return nil, err
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+type averageAggregator struct {
+	path  string
+	sum   float64
+	count float64
+}
+
+// NewAverage returns an Aggregator that computes the mean of the numbers
+// extracted at path.
+func NewAverage(path string) Aggregator {
+	return &averageAggregator{path: path}
+}
+
+func (a *averageAggregator) Push(r *api.Record) error {
+	number, err := ExtractNumber(r, a.path)
+	if err != nil {
+		return err
+	}
+	if number != nil {
+		a.sum += *number
+		a.count++
+	}
+	return nil
+}
+
+func (a *averageAggregator) Result() (any, error) {
+	if a.count == 0 {
+		return (*float64)(nil), nil
+	}
+	return pointer(a.sum / a.count), nil
+}
+
+// stdDevAggregator computes the population standard deviation in a single
+// pass using Welford's online algorithm.
+type stdDevAggregator struct {
+	path  string
+	count float64
+	mean  float64
+	m2    float64
+}
+
+// NewStdDev returns an Aggregator that computes the population standard
+// deviation of the numbers extracted at path.
+func NewStdDev(path string) Aggregator {
+	return &stdDevAggregator{path: path}
+}
+
+func (a *stdDevAggregator) Push(r *api.Record) error {
+	number, err := ExtractNumber(r, a.path)
+	if err != nil {
+		return err
+	}
+	if number == nil {
+		return nil
+	}
+	a.count++
+	delta := *number - a.mean
+	a.mean += delta / a.count
+	a.m2 += delta * (*number - a.mean)
+	return nil
+}
+
+func (a *stdDevAggregator) Result() (any, error) {
+	if a.count == 0 {
+		return (*float64)(nil), nil
+	}
+	return pointer(math.Sqrt(a.m2 / a.count)), nil
+}
+
+type minAggregator struct {
+	path string
+	min  float64
+	set  bool
+}
+
+// NewMin returns an Aggregator that computes the smallest number extracted
+// at path.
+func NewMin(path string) Aggregator {
+	return &minAggregator{path: path}
+}
+
+func (a *minAggregator) Push(r *api.Record) error {
+	number, err := ExtractNumber(r, a.path)
+	if err != nil {
+		return err
+	}
+	if number == nil {
+		return nil
+	}
+	if !a.set || *number < a.min {
+		a.min = *number
+		a.set = true
+	}
+	return nil
+}
+
+func (a *minAggregator) Result() (any, error) {
+	if !a.set {
+		return (*float64)(nil), nil
+	}
+	return pointer(a.min), nil
+}
+
+type maxAggregator struct {
+	path string
+	max  float64
+	set  bool
+}
+
+// NewMax returns an Aggregator that computes the largest number extracted at
+// path.
+func NewMax(path string) Aggregator {
+	return &maxAggregator{path: path}
+}
+
+func (a *maxAggregator) Push(r *api.Record) error {
+	number, err := ExtractNumber(r, a.path)
+	if err != nil {
+		return err
+	}
+	if number == nil {
+		return nil
+	}
+	if !a.set || *number > a.max {
+		a.max = *number
+		a.set = true
+	}
+	return nil
+}
+
+func (a *maxAggregator) Result() (any, error) {
+	if !a.set {
+		return (*float64)(nil), nil
+	}
+	return pointer(a.max), nil
+}
+
+type sumAggregator struct {
+	path  string
+	sum   float64
+	count int
+}
+
+// NewSum returns an Aggregator that computes the sum of the numbers
+// extracted at path.
+func NewSum(path string) Aggregator {
+	return &sumAggregator{path: path}
+}
+
+func (a *sumAggregator) Push(r *api.Record) error {
+	number, err := ExtractNumber(r, a.path)
+	if err != nil {
+		return err
+	}
+	if number != nil {
+		a.sum += *number
+		a.count++
+	}
+	return nil
+}
+
+func (a *sumAggregator) Result() (any, error) {
+	if a.count == 0 {
+		return (*float64)(nil), nil
+	}
+	return pointer(a.sum), nil
+}
@@ -0,0 +1,72 @@
+package record_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tilotech/tilores-insights/helpers"
+	"github.com/tilotech/tilores-insights/record"
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+func pushAll(t *testing.T, agg record.Aggregator, records []*api.Record) any {
+	t.Helper()
+	for _, r := range records {
+		require.NoError(t, agg.Push(r))
+	}
+	result, err := agg.Result()
+	require.NoError(t, err)
+	return result
+}
+
+func TestAggregators(t *testing.T) {
+	records := valueRecords(t, []any{1, 2, nil, 3})
+
+	avg := pushAll(t, record.NewAverage("value"), records)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(2.0), avg.(*float64))
+
+	stdDev := pushAll(t, record.NewStdDev("value"), records)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(0.816496580927726), stdDev.(*float64))
+
+	min := pushAll(t, record.NewMin("value"), records)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(1.0), min.(*float64))
+
+	max := pushAll(t, record.NewMax("value"), records)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(3.0), max.(*float64))
+
+	sum := pushAll(t, record.NewSum("value"), records)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(6.0), sum.(*float64))
+}
+
+func TestAggregatorEmpty(t *testing.T) {
+	result := pushAll(t, record.NewAverage("value"), nil)
+	assert.Nil(t, result.(*float64))
+}
+
+func TestCombine(t *testing.T) {
+	records := valueRecords(t, []any{1, 2, 3})
+	combined := record.Combine(record.NewSum("value"), record.NewMin("value"), record.NewMax("value"))
+	result := pushAll(t, combined, records)
+
+	results, ok := result.([]any)
+	require.True(t, ok)
+	require.Len(t, results, 3)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(6.0), results[0].(*float64))
+	assertFloatPointerEqual(t, helpers.NullifyFloat(1.0), results[1].(*float64))
+	assertFloatPointerEqual(t, helpers.NullifyFloat(3.0), results[2].(*float64))
+}
+
+func TestAverageStandardDeviationMatchStreaming(t *testing.T) {
+	records := valueRecords(t, []any{1, 2, 3, 4, 5})
+
+	avg, err := record.Average(records, "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(3.0), avg)
+	assertFloatPointerEqual(t, pushAll(t, record.NewAverage("value"), records).(*float64), avg)
+
+	stdDev, err := record.StandardDeviation(records, "value")
+	require.NoError(t, err)
+	assertFloatPointerEqual(t, helpers.NullifyFloat(1.4142135623730951), stdDev)
+	assertFloatPointerEqual(t, pushAll(t, record.NewStdDev("value"), records).(*float64), stdDev)
+}
@@ -4,24 +4,17 @@ import (
 	api "github.com/tilotech/tilores-plugin-api"
 )
 
+// Average returns the mean of the numbers extracted at path across records.
 func Average(records []*api.Record, path string) (*float64, error) {
-	if len(records) == 0 {
-		return nil, nil
-	}
-	sum := 0.0
-	counted := 0.0
+	agg := NewAverage(path)
 	for _, record := range records {
-		number, err := ExtractNumber(record, path)
-		if err != nil {
+		if err := agg.Push(record); err != nil {
 			return nil, err
 		}
-		if number != nil {
-			sum += *number
-			counted++
-		}
 	}
-	if counted == 0 {
-		return nil, nil
+	result, err := agg.Result()
+	if err != nil {
+		return nil, err
 	}
-	return pointer(sum / counted), nil
+	return result.(*float64), nil
 }
@@ -0,0 +1,38 @@
+package record
+
+import (
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+// Count returns the number of non-nil values extracted at path.
+func Count(records []*api.Record, path string) (*float64, error) {
+	count := 0
+	for _, record := range records {
+		if Extract(record, path) != nil {
+			count++
+		}
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	return pointer(float64(count)), nil
+}
+
+// CountDistinct returns the number of distinct non-nil values extracted at
+// path.
+func CountDistinct(records []*api.Record, path string) (*float64, error) {
+	seen := make(map[string]struct{}, len(records))
+	for _, record := range records {
+		value, err := ExtractString(record, path, true)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			seen[*value] = struct{}{}
+		}
+	}
+	if len(seen) == 0 {
+		return nil, nil
+	}
+	return pointer(float64(len(seen))), nil
+}
@@ -0,0 +1,89 @@
+// Package deepcopy recursively clones JSON-shaped values (the value space
+// produced by encoding/json unmarshalling into any) so that a caller can
+// mutate a clone without affecting the original.
+package deepcopy
+
+import "fmt"
+
+// Clone returns a deep copy of v. v must be built exclusively from the JSON
+// value space: nil, bool, numeric kinds, string, map[string]any and []any.
+// Of pointers, only *bool, *string, *int, *int64 and *float64 are cloned; any
+// other pointer type, including pointers to other numeric kinds, maps or
+// slices, makes Clone return an error.
+func Clone[T any](v T) (T, error) {
+	cloned, err := clone(v)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	if cloned == nil {
+		var zero T
+		return zero, nil
+	}
+	typed, ok := cloned.(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("deepcopy: cloned value has unexpected type %T", cloned)
+	}
+	return typed, nil
+}
+
+func clone(v any) (any, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case bool, string,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return val, nil
+	case map[string]any:
+		return cloneObject(val)
+	case []any:
+		return cloneVector(val)
+	case *bool:
+		return clonePointer(val), nil
+	case *string:
+		return clonePointer(val), nil
+	case *int:
+		return clonePointer(val), nil
+	case *int64:
+		return clonePointer(val), nil
+	case *float64:
+		return clonePointer(val), nil
+	default:
+		return nil, fmt.Errorf("deepcopy: unsupported type %T", v)
+	}
+}
+
+func cloneObject(m map[string]any) (map[string]any, error) {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		cloned, err := clone(v)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = cloned
+	}
+	return out, nil
+}
+
+func cloneVector(s []any) ([]any, error) {
+	out := make([]any, len(s))
+	for i, v := range s {
+		cloned, err := clone(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = cloned
+	}
+	return out, nil
+}
+
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
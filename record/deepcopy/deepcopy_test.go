@@ -0,0 +1,47 @@
+package deepcopy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tilotech/tilores-insights/record/deepcopy"
+)
+
+func TestClone(t *testing.T) {
+	original := map[string]any{
+		"value": "string",
+		"nested": map[string]any{
+			"list": []any{"a", "b"},
+		},
+		"nullValue": nil,
+	}
+
+	cloned, err := deepcopy.Clone(original)
+	require.NoError(t, err)
+	assert.Equal(t, original, cloned)
+
+	cloned["value"] = "mutated"
+	cloned["nested"].(map[string]any)["list"].([]any)[0] = "mutated"
+	assert.Equal(t, "string", original["value"])
+	assert.Equal(t, "a", original["nested"].(map[string]any)["list"].([]any)[0])
+}
+
+func TestClonePointer(t *testing.T) {
+	value := 42
+	cloned, err := deepcopy.Clone(&value)
+	require.NoError(t, err)
+	require.NotSame(t, &value, cloned)
+	assert.Equal(t, value, *cloned)
+}
+
+func TestCloneUnsupportedType(t *testing.T) {
+	_, err := deepcopy.Clone(make(chan int))
+	assert.Error(t, err)
+}
+
+func TestCloneNilInterface(t *testing.T) {
+	cloned, err := deepcopy.Clone[any](nil)
+	require.NoError(t, err)
+	assert.Nil(t, cloned)
+}
@@ -0,0 +1,15 @@
+package record
+
+import (
+	"github.com/tilotech/tilores-insights/record/deepcopy"
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+// ExtractCopy behaves like Extract, but returns a deep copy of the result so
+// that the caller can mutate it without corrupting the underlying record.
+// If the result holds a value that deepcopy.Clone cannot clone, ExtractCopy
+// returns nil.
+func ExtractCopy(r *api.Record, path string) any {
+	cloned, _ := deepcopy.Clone(Extract(r, path))
+	return cloned
+}
@@ -0,0 +1,28 @@
+package record_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tilotech/tilores-insights/record"
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+func TestExtractCopy(t *testing.T) {
+	dataJSON := `{"nested": {"value": "original"}, "list": ["a", "b"]}`
+	data := map[string]any{}
+	require.NoError(t, json.Unmarshal([]byte(dataJSON), &data))
+
+	r := &api.Record{ID: "some-id", Data: data}
+
+	nested := record.ExtractCopy(r, "nested").(map[string]any)
+	nested["value"] = "mutated"
+	list := record.ExtractCopy(r, "list").([]any)
+	list[0] = "mutated"
+
+	again := record.ExtractCopy(r, "nested").(map[string]any)
+	require.Equal(t, "original", again["value"])
+	again2 := record.ExtractCopy(r, "list").([]any)
+	require.Equal(t, "a", again2[0])
+}
@@ -0,0 +1,169 @@
+package record
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExtractRaw behaves like Extract, but walks data's JSON token stream
+// directly instead of unmarshalling it first, materialising only the
+// subtree found at path. Path semantics are identical to Extract:
+// dot-separated segments, numeric segments index into arrays, and a missing
+// key, an out-of-range (or negative) index, or a null value all resolve to
+// nil rather than an error.
+func ExtractRaw(data []byte, path string) (any, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	return walkRaw(dec, strings.Split(path, "."))
+}
+
+// ExtractNumberRaw behaves like ExtractNumber, but extracts via ExtractRaw.
+func ExtractNumberRaw(data []byte, path string) (*float64, error) {
+	value, err := ExtractRaw(data, path)
+	if err != nil {
+		return nil, err
+	}
+	return numberFromAny(value)
+}
+
+// ExtractStringRaw behaves like ExtractString, but extracts via ExtractRaw.
+func ExtractStringRaw(data []byte, path string, caseSensitive bool) (*string, error) {
+	value, err := ExtractRaw(data, path)
+	if err != nil {
+		return nil, err
+	}
+	return stringFromAny(value, caseSensitive)
+}
+
+func walkRaw(dec *json.Decoder, segments []string) (any, error) {
+	if len(segments) == 0 {
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		// a scalar or null was reached, but there are still path segments
+		// left to resolve against it
+		return nil, nil
+	}
+
+	switch delim {
+	case '{':
+		return walkRawObject(dec, segments)
+	case '[':
+		return walkRawArray(dec, segments)
+	default:
+		return nil, fmt.Errorf("record: unexpected JSON token %v", tok)
+	}
+}
+
+func walkRawObject(dec *json.Decoder, segments []string) (any, error) {
+	key := segments[0]
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if tok.(string) == key {
+			return walkRaw(dec, segments[1:])
+		}
+		if err := skipRawValue(dec); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func walkRawArray(dec *json.Decoder, segments []string) (any, error) {
+	index, err := strconv.Atoi(segments[0])
+	if err != nil || index < 0 {
+		return nil, nil
+	}
+	for i := 0; dec.More(); i++ {
+		if i == index {
+			return walkRaw(dec, segments[1:])
+		}
+		if err := skipRawValue(dec); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// skipRawValue consumes the next JSON value from dec without materialising
+// it, so that unwanted subtrees never get unmarshalled.
+func skipRawValue(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || (delim != '{' && delim != '[') {
+		return nil
+	}
+	depth := 1
+	for depth > 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if d, ok := tok.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+	}
+	return nil
+}
+
+func numberFromAny(v any) (*float64, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		return &val, nil
+	case string:
+		number, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("record: value %q is not numeric", val)
+		}
+		return &number, nil
+	default:
+		return nil, fmt.Errorf("record: value of type %T is not numeric", v)
+	}
+}
+
+func stringFromAny(v any, caseSensitive bool) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		s = string(b)
+	}
+	if !caseSensitive {
+		s = strings.ToLower(s)
+	}
+	return &s, nil
+}
@@ -0,0 +1,191 @@
+package record_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tilotech/tilores-insights/helpers"
+	"github.com/tilotech/tilores-insights/record"
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+const extractRawDataJSON = `
+{
+	"value": "string",
+	"nested": {
+		"value": "nested string value",
+		"super": {
+			"value": "Super Nested String Value"
+		}
+	},
+	"int": 123,
+	"list": [
+		"abc",
+		"DEF",
+		"geh"
+	],
+	"nullValue": null,
+	"emptyString": ""
+}
+`
+
+func TestExtractRaw(t *testing.T) {
+	cases := map[string]any{
+		"value":                    "string",
+		"nested.value":             "nested string value",
+		"nested.super.value":       "Super Nested String Value",
+		"int":                      123.0,
+		"list.0":                   "abc",
+		"list.1":                   "DEF",
+		"list.2":                   "geh",
+		"nonexistent":              nil,
+		"non.existent":             nil,
+		"nested.nonexistent":       nil,
+		"nested.value.nonexistent": nil,
+		"int.nonexistent":          nil,
+		"list.a":                   nil,
+		"list.4":                   nil,
+		"list.-1":                  nil,
+		"nullValue":                nil,
+		"emptyString":              "",
+	}
+
+	for path, expected := range cases {
+		t.Run(path, func(t *testing.T) {
+			actual, err := record.ExtractRaw([]byte(extractRawDataJSON), path)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("nested object", func(t *testing.T) {
+		actual, err := record.ExtractRaw([]byte(extractRawDataJSON), "nested")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"value": "nested string value",
+			"super": map[string]any{
+				"value": "Super Nested String Value",
+			},
+		}, actual)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		actual, err := record.ExtractRaw([]byte(extractRawDataJSON), "list")
+		require.NoError(t, err)
+		assert.Equal(t, []any{"abc", "DEF", "geh"}, actual)
+	})
+
+	t.Run("empty data", func(t *testing.T) {
+		actual, err := record.ExtractRaw(nil, "value")
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestExtractNumberRaw(t *testing.T) {
+	dataJSON := `{"nonnumeric": "string", "int": 123, "numericText": "123", "nullValue": null}`
+
+	cases := map[string]struct {
+		expected    *float64
+		expectError bool
+	}{
+		"nonnumeric":  {expectError: true},
+		"int":         {expected: helpers.NullifyFloat(123.0)},
+		"numericText": {expected: helpers.NullifyFloat(123.0)},
+		"nullValue":   {expected: nil},
+	}
+
+	for path, c := range cases {
+		t.Run(path, func(t *testing.T) {
+			actual, err := record.ExtractNumberRaw([]byte(dataJSON), path)
+			if c.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if c.expected == nil {
+				assert.Nil(t, actual)
+			} else {
+				require.NotNil(t, actual)
+				assert.Equal(t, *c.expected, *actual)
+			}
+		})
+	}
+}
+
+func TestExtractStringRaw(t *testing.T) {
+	dataJSON := `{"keepUpper": "Has Upper Case", "int": 123, "nullValue": null}`
+
+	t.Run("case insensitive by default", func(t *testing.T) {
+		actual, err := record.ExtractStringRaw([]byte(dataJSON), "keepUpper", false)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, "has upper case", *actual)
+	})
+
+	t.Run("case sensitive", func(t *testing.T) {
+		actual, err := record.ExtractStringRaw([]byte(dataJSON), "keepUpper", true)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, "Has Upper Case", *actual)
+	})
+
+	t.Run("number", func(t *testing.T) {
+		actual, err := record.ExtractStringRaw([]byte(dataJSON), "int", true)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, "123", *actual)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		actual, err := record.ExtractStringRaw([]byte(dataJSON), "nullValue", true)
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+// largeRawPayload builds a synthetic record with a deeply nested payload and
+// a single shallow field, to demonstrate that ExtractRaw does not pay the
+// cost of unmarshalling the whole document just to read that field.
+func largeRawPayload(b *testing.B) []byte {
+	b.Helper()
+	nested := map[string]any{}
+	cursor := nested
+	for i := 0; i < 1000; i++ {
+		child := map[string]any{
+			"index": i,
+			"text":  fmt.Sprintf("value-%d", i),
+		}
+		cursor["child"] = child
+		cursor = child
+	}
+	payload := map[string]any{
+		"target": "needle",
+		"nested": nested,
+	}
+	data, err := json.Marshal(payload)
+	require.NoError(b, err)
+	return data
+}
+
+func BenchmarkExtract(b *testing.B) {
+	data := largeRawPayload(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parsed := map[string]any{}
+		require.NoError(b, json.Unmarshal(data, &parsed))
+		_ = record.Extract(&api.Record{Data: parsed}, "target")
+	}
+}
+
+func BenchmarkExtractRaw(b *testing.B) {
+	data := largeRawPayload(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := record.ExtractRaw(data, "target")
+		require.NoError(b, err)
+	}
+}
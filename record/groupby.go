@@ -0,0 +1,31 @@
+package record
+
+import (
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+// GroupBy partitions records by the string value extracted at groupPath and
+// applies agg to each partition, keyed by that value. Records with no value
+// at groupPath are excluded from every group.
+func GroupBy(records []*api.Record, groupPath string, agg func([]*api.Record) (any, error)) (map[string]any, error) {
+	groups := make(map[string][]*api.Record)
+	for _, record := range records {
+		key, err := ExtractString(record, groupPath, true)
+		if err != nil {
+			return nil, err
+		}
+		if key == nil {
+			continue
+		}
+		groups[*key] = append(groups[*key], record)
+	}
+	result := make(map[string]any, len(groups))
+	for key, group := range groups {
+		value, err := agg(group)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
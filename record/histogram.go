@@ -0,0 +1,57 @@
+package record
+
+import (
+	"fmt"
+
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+// Bucket is a single equal-width bucket of a Histogram.
+type Bucket struct {
+	Lower float64
+	Upper float64
+	Count int
+}
+
+// Histogram buckets the numbers extracted at path into the given number of
+// equal-width buckets spanning the observed minimum and maximum. It returns
+// nil if no numbers were extracted.
+func Histogram(records []*api.Record, path string, buckets int) ([]Bucket, error) {
+	if buckets <= 0 {
+		return nil, fmt.Errorf("buckets must be positive, got %d", buckets)
+	}
+	numbers, err := extractNumbers(records, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+	min, max := numbers[0], numbers[0]
+	for _, number := range numbers[1:] {
+		if number < min {
+			min = number
+		}
+		if number > max {
+			max = number
+		}
+	}
+	width := (max - min) / float64(buckets)
+	result := make([]Bucket, buckets)
+	for i := range result {
+		result[i].Lower = min + float64(i)*width
+		result[i].Upper = min + float64(i+1)*width
+	}
+	if width == 0 {
+		result[0].Count = len(numbers)
+		return result, nil
+	}
+	for _, number := range numbers {
+		idx := int((number - min) / width)
+		if idx >= buckets {
+			idx = buckets - 1
+		}
+		result[idx].Count++
+	}
+	return result, nil
+}
@@ -0,0 +1,41 @@
+package record
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+// Median returns the 50th percentile of the numbers extracted at path.
+func Median(records []*api.Record, path string) (*float64, error) {
+	return Percentile(records, path, 50)
+}
+
+// Percentile returns the p-th percentile (0-100) of the numbers extracted at
+// path, linearly interpolating between the two closest ranks.
+func Percentile(records []*api.Record, path string, p float64) (*float64, error) {
+	if p < 0 || p > 100 {
+		return nil, fmt.Errorf("record: percentile %v is out of range [0,100]", p)
+	}
+	numbers, err := extractNumbers(records, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+	sort.Float64s(numbers)
+	if len(numbers) == 1 {
+		return pointer(numbers[0]), nil
+	}
+	rank := (p / 100) * float64(len(numbers)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return pointer(numbers[lower]), nil
+	}
+	frac := rank - float64(lower)
+	return pointer(numbers[lower] + (numbers[upper]-numbers[lower])*frac), nil
+}
@@ -0,0 +1,41 @@
+package record
+
+import (
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+// Min returns the smallest number extracted at path across records.
+func Min(records []*api.Record, path string) (*float64, error) {
+	numbers, err := extractNumbers(records, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+	min := numbers[0]
+	for _, number := range numbers[1:] {
+		if number < min {
+			min = number
+		}
+	}
+	return pointer(min), nil
+}
+
+// Max returns the largest number extracted at path across records.
+func Max(records []*api.Record, path string) (*float64, error) {
+	numbers, err := extractNumbers(records, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+	max := numbers[0]
+	for _, number := range numbers[1:] {
+		if number > max {
+			max = number
+		}
+	}
+	return pointer(max), nil
+}
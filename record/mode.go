@@ -0,0 +1,41 @@
+package record
+
+import (
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+// Mode returns the most frequent number extracted at path, with ties broken
+// by the smallest value.
+func Mode(records []*api.Record, path string) (*float64, error) {
+	numbers, err := extractNumbers(records, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+	counts := make(map[float64]int, len(numbers))
+	for _, number := range numbers {
+		counts[number]++
+	}
+	best := numbers[0]
+	for number, count := range counts {
+		if count > counts[best] || (count == counts[best] && number < best) {
+			best = number
+		}
+	}
+	return pointer(best), nil
+}
+
+// TextMode returns the most frequent string extracted at path, with ties
+// broken by shortest length, then lexicographically smallest.
+func TextMode(records []*api.Record, path string, caseSensitive bool) (*string, error) {
+	strs, err := extractStrings(records, path, caseSensitive)
+	if err != nil {
+		return nil, err
+	}
+	if len(strs) == 0 {
+		return nil, nil
+	}
+	return pointer(textMode(strs)), nil
+}
@@ -0,0 +1,20 @@
+package record
+
+import (
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+// extractNumbers extracts the non-nil numbers at path across records.
+func extractNumbers(records []*api.Record, path string) ([]float64, error) {
+	numbers := make([]float64, 0, len(records))
+	for _, record := range records {
+		number, err := ExtractNumber(record, path)
+		if err != nil {
+			return nil, err
+		}
+		if number != nil {
+			numbers = append(numbers, *number)
+		}
+	}
+	return numbers, nil
+}
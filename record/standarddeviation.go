@@ -1,36 +1,21 @@
 package record
 
 import (
-	"math"
-
 	api "github.com/tilotech/tilores-plugin-api"
 )
 
+// StandardDeviation returns the population standard deviation of the numbers
+// extracted at path across records, computed in a single pass.
 func StandardDeviation(records []*api.Record, path string) (*float64, error) {
-	if len(records) == 0 {
-		return nil, nil
-	}
-	avg, err := Average(records, path)
-	if err != nil {
-		return nil, err
-	}
-	difSquareSum := 0.0
-	counted := 0.0
+	agg := NewStdDev(path)
 	for _, record := range records {
-		number, err := ExtractNumber(record, path)
-		if err != nil {
+		if err := agg.Push(record); err != nil {
 			return nil, err
 		}
-		if number != nil {
-			dif := *number - *avg
-			difSquareSum += dif * dif
-			counted++
-		}
 	}
-	if counted == 0 {
-		return nil, nil
+	result, err := agg.Result()
+	if err != nil {
+		return nil, err
 	}
-	return pointer(math.Sqrt(difSquareSum / counted)), nil
+	return result.(*float64), nil
 }
-
-// TODO: Add text standard deviation
@@ -0,0 +1,21 @@
+package record
+
+import (
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+// Sum returns the sum of the numbers extracted at path across records.
+func Sum(records []*api.Record, path string) (*float64, error) {
+	numbers, err := extractNumbers(records, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+	sum := 0.0
+	for _, number := range numbers {
+		sum += number
+	}
+	return pointer(sum), nil
+}
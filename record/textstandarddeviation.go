@@ -0,0 +1,152 @@
+package record
+
+import (
+	"math"
+
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+// TextAverage computes the centroid of the strings extracted at path and the
+// mean normalized Levenshtein distance of those strings to the centroid. The
+// centroid is the mode of the extracted strings, with ties broken by
+// shortest length, then lexicographically smallest.
+func TextAverage(records []*api.Record, path string, caseSensitive bool) (*string, *float64, error) {
+	if len(records) == 0 {
+		return nil, nil, nil
+	}
+	strs, err := extractStrings(records, path, caseSensitive)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(strs) == 0 {
+		return nil, nil, nil
+	}
+	centroid := textMode(strs)
+	sum := 0.0
+	for _, s := range strs {
+		sum += normalizedLevenshtein(s, centroid)
+	}
+	return pointer(centroid), pointer(sum / float64(len(strs))), nil
+}
+
+// TextStandardDeviation measures the dispersion of the strings extracted at
+// path around their centroid (see TextAverage), using normalized Levenshtein
+// distance in place of numeric distance.
+func TextStandardDeviation(records []*api.Record, path string, caseSensitive bool) (*float64, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+	strs, err := extractStrings(records, path, caseSensitive)
+	if err != nil {
+		return nil, err
+	}
+	if len(strs) == 0 {
+		return nil, nil
+	}
+	centroid := textMode(strs)
+	distances := make([]float64, len(strs))
+	sum := 0.0
+	for i, s := range strs {
+		d := normalizedLevenshtein(s, centroid)
+		distances[i] = d
+		sum += d
+	}
+	mean := sum / float64(len(strs))
+	difSquareSum := 0.0
+	for _, d := range distances {
+		dif := d - mean
+		difSquareSum += dif * dif
+	}
+	return pointer(math.Sqrt(difSquareSum / float64(len(strs)))), nil
+}
+
+func extractStrings(records []*api.Record, path string, caseSensitive bool) ([]string, error) {
+	strs := make([]string, 0, len(records))
+	for _, record := range records {
+		s, err := ExtractString(record, path, caseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		if s != nil {
+			strs = append(strs, *s)
+		}
+	}
+	return strs, nil
+}
+
+// textMode returns the most frequent string, with ties broken by shortest
+// length, then lexicographically smallest.
+func textMode(strs []string) string {
+	counts := make(map[string]int, len(strs))
+	for _, s := range strs {
+		counts[s]++
+	}
+	best := strs[0]
+	for s, count := range counts {
+		switch {
+		case count > counts[best]:
+			best = s
+		case count == counts[best] && len(s) < len(best):
+			best = s
+		case count == counts[best] && len(s) == len(best) && s < best:
+			best = s
+		}
+	}
+	return best
+}
+
+// normalizedLevenshtein returns the Levenshtein distance between a and b,
+// normalized by the length of the longer string. Two empty strings have a
+// distance of 0.
+func normalizedLevenshtein(a, b string) float64 {
+	max := len([]rune(a))
+	if rb := len([]rune(b)); rb > max {
+		max = rb
+	}
+	if max == 0 {
+		return 0
+	}
+	return float64(levenshtein(a, b)) / float64(max)
+}
+
+// levenshtein returns the Levenshtein edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+	la := len(ra)
+	lb := len(rb)
+	if la == 0 {
+		return lb
+	}
+	if lb == 0 {
+		return la
+	}
+	prev := make([]int, lb+1)
+	curr := make([]int, lb+1)
+	for j := 0; j <= lb; j++ {
+		prev[j] = j
+	}
+	for i := 1; i <= la; i++ {
+		curr[0] = i
+		for j := 1; j <= lb; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[lb]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
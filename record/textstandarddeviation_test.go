@@ -0,0 +1,73 @@
+package record_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tilotech/tilores-insights/helpers"
+	"github.com/tilotech/tilores-insights/record"
+)
+
+func TestTextStandardDeviation(t *testing.T) {
+	cases := map[string]struct {
+		values        []any
+		caseSensitive bool
+		expected      *float64
+	}{
+		"identical strings": {
+			values:   []any{"abc", "abc", "abc"},
+			expected: helpers.NullifyFloat(0.0),
+		},
+		"case insensitive by default": {
+			values:   []any{"abc", "ABC", "abc"},
+			expected: helpers.NullifyFloat(0.0),
+		},
+		"case sensitive": {
+			values:        []any{"abc", "ABC", "abc"},
+			caseSensitive: true,
+			expected:      helpers.NullifyFloat(0.4714045207910317),
+		},
+		"no records": {
+			values:   nil,
+			expected: nil,
+		},
+		"all null": {
+			values:   []any{nil, nil},
+			expected: nil,
+		},
+		"mix of null and values": {
+			values:   []any{"abc", nil, "abd"},
+			expected: helpers.NullifyFloat(0.16666666666666666),
+		},
+		"multi-byte runes normalize by rune length": {
+			values:        []any{"漢", "漢", ""},
+			caseSensitive: true,
+			expected:      helpers.NullifyFloat(0.4714045207910317),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			records := valueRecords(t, c.values)
+			actual, err := record.TextStandardDeviation(records, "value", c.caseSensitive)
+			require.NoError(t, err)
+			if c.expected == nil {
+				assert.Nil(t, actual)
+			} else {
+				require.NotNil(t, actual)
+				assert.InDelta(t, *c.expected, *actual, 0.0000001)
+			}
+		})
+	}
+}
+
+func TestTextAverage(t *testing.T) {
+	records := valueRecords(t, []any{"abc", "abc", "abd"})
+	centroid, avg, err := record.TextAverage(records, "value", false)
+	require.NoError(t, err)
+	require.NotNil(t, centroid)
+	require.NotNil(t, avg)
+	assert.Equal(t, "abc", *centroid)
+	assert.InDelta(t, 0.1111111111111111, *avg, 0.0000001)
+}
@@ -0,0 +1,29 @@
+package record
+
+import (
+	api "github.com/tilotech/tilores-plugin-api"
+)
+
+// Variance returns the population variance of the numbers extracted at path.
+func Variance(records []*api.Record, path string) (*float64, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+	avg, err := Average(records, path)
+	if err != nil {
+		return nil, err
+	}
+	if avg == nil {
+		return nil, nil
+	}
+	numbers, err := extractNumbers(records, path)
+	if err != nil {
+		return nil, err
+	}
+	difSquareSum := 0.0
+	for _, number := range numbers {
+		dif := number - *avg
+		difSquareSum += dif * dif
+	}
+	return pointer(difSquareSum / float64(len(numbers))), nil
+}